@@ -1,19 +1,37 @@
 package blocking_dequeue
 
 import (
+	"context"
+	"errors"
 	"sync"
+	"time"
 )
 
+// Returned by blocking and context-aware operations once the dequeue has been Close'd.
+var ErrDisposed = errors.New("blocking_dequeue: dequeue is disposed")
+
 // Blocking dequeue, implemented with a circular buffer.
 // The dequeue is thread safe. And must not be copied.
 type BlockingDequeue[T any] struct {
 	buffer []T
 
-	lock              *sync.Mutex
-	notEmpty, notFull *sync.Cond
+	lock *sync.Mutex
+
+	// Waiters are notified by closing their channel, which lets them select
+	// on ctx.Done() instead of blocking uninterruptibly like sync.Cond.Wait does.
+	notEmptyWaiters, notFullWaiters []chan struct{}
 
 	first, last int
 	isEmpty     bool
+
+	// When unbounded is set, the buffer grows/shrinks by powers of two instead of
+	// blocking on push, and never shrinks below minCapacity.
+	unbounded   bool
+	minCapacity int
+
+	// Set by Close. closedCh is closed alongside it, for Closed()/select-based shutdown.
+	disposed bool
+	closedCh chan struct{}
 }
 
 // Creates a new blocking dequeue with infinite capacity.
@@ -28,8 +46,33 @@ func NewBlockingDequeue[T any](buffer []T) *BlockingDequeue[T] {
 	d.isEmpty = true
 
 	d.lock = &sync.Mutex{}
-	d.notEmpty = sync.NewCond(d.lock)
-	d.notFull = sync.NewCond(d.lock)
+	d.closedCh = make(chan struct{})
+
+	return d
+}
+
+// Creates a new blocking dequeue in unbounded mode: PushFront/PushBack never block and
+// IsFull always returns false. The backing buffer starts at initialCap (at least 1),
+// doubles when full, and halves when occupancy drops below a quarter of capacity, never
+// shrinking below initialCap.
+func NewUnboundedBlockingDequeue[T any](initialCap int) *BlockingDequeue[T] {
+	if initialCap < 1 {
+		initialCap = 1
+	}
+
+	d := new(BlockingDequeue[T])
+
+	d.buffer = make([]T, initialCap)
+
+	d.first = 0
+	d.last = 0
+	d.isEmpty = true
+
+	d.lock = &sync.Mutex{}
+	d.closedCh = make(chan struct{})
+
+	d.unbounded = true
+	d.minCapacity = initialCap
 
 	return d
 }
@@ -43,18 +86,178 @@ func (d BlockingDequeue[T]) prevIndex(i int) int {
 	return (i - 1 + len(d.buffer)) % len(d.buffer)
 }
 
+// =================================[Waiter helpers]=================================
+// All of the following must be called while holding d.lock.
+
+// Registers a new waiter on the given list and returns the channel it will be woken up on.
+func (d *BlockingDequeue[T]) addWaiter(waiters *[]chan struct{}) chan struct{} {
+	w := make(chan struct{})
+	*waiters = append(*waiters, w)
+	return w
+}
+
+// Removes a waiter from the given list without waking it up, used when a wait is cancelled.
+func (d *BlockingDequeue[T]) removeWaiter(waiters *[]chan struct{}, target chan struct{}) {
+	for i, w := range *waiters {
+		if w == target {
+			*waiters = append((*waiters)[:i], (*waiters)[i+1:]...)
+			return
+		}
+	}
+}
+
+// Wakes up the oldest waiter on the given list, if any. Mirrors sync.Cond.Signal.
+func (d *BlockingDequeue[T]) signalOne(waiters *[]chan struct{}) {
+	if len(*waiters) == 0 {
+		return
+	}
+
+	w := (*waiters)[0]
+	*waiters = (*waiters)[1:]
+	close(w)
+}
+
+// Wakes up every waiter on the given list. Mirrors sync.Cond.Broadcast.
+func (d *BlockingDequeue[T]) broadcastAll(waiters *[]chan struct{}) {
+	for _, w := range *waiters {
+		close(w)
+	}
+	*waiters = nil
+}
+
+// Blocks until the dequeue is not full, returning false if it's disposed in the meantime.
+// Must be called while holding d.lock, which is released while actually waiting and
+// re-acquired before returning. In unbounded mode this never blocks: it grows the buffer
+// instead.
+func (d *BlockingDequeue[T]) waitNotFull() bool {
+	if d.disposed {
+		return false
+	}
+
+	if d.unbounded {
+		d.growIfFull_unsafe()
+		return true
+	}
+
+	for d.isFull_unsafe() {
+		w := d.addWaiter(&d.notFullWaiters)
+		d.lock.Unlock()
+		<-w
+		d.lock.Lock()
+
+		if d.disposed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Blocks until the dequeue is not empty. Same contract as waitNotFull.
+func (d *BlockingDequeue[T]) waitNotEmpty() bool {
+	if d.disposed {
+		return false
+	}
+
+	for d.isEmpty_unsafe() {
+		w := d.addWaiter(&d.notEmptyWaiters)
+		d.lock.Unlock()
+		<-w
+		d.lock.Lock()
+
+		if d.disposed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Same as waitNotFull, but also wakes up and returns ctx.Err() if ctx is done before
+// the dequeue stops being full. The waiter is removed from the wait list on cancellation
+// so it doesn't leak or swallow a wake-up meant for another waiter.
+func (d *BlockingDequeue[T]) waitNotFullContext(ctx context.Context) error {
+	if d.disposed {
+		return ErrDisposed
+	}
+
+	if d.unbounded {
+		d.growIfFull_unsafe()
+		return nil
+	}
+
+	for d.isFull_unsafe() {
+		w := d.addWaiter(&d.notFullWaiters)
+		d.lock.Unlock()
+
+		select {
+		case <-w:
+			d.lock.Lock()
+			if d.disposed {
+				return ErrDisposed
+			}
+		case <-ctx.Done():
+			d.lock.Lock()
+			select {
+			case <-w:
+				// Already signalled: hand the wake-up on to the next waiter.
+				d.signalOne(&d.notFullWaiters)
+			default:
+				d.removeWaiter(&d.notFullWaiters, w)
+			}
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// Same as waitNotEmpty, but also wakes up and returns ctx.Err() if ctx is done before
+// the dequeue stops being empty.
+func (d *BlockingDequeue[T]) waitNotEmptyContext(ctx context.Context) error {
+	if d.disposed {
+		return ErrDisposed
+	}
+
+	for d.isEmpty_unsafe() {
+		w := d.addWaiter(&d.notEmptyWaiters)
+		d.lock.Unlock()
+
+		select {
+		case <-w:
+			d.lock.Lock()
+			if d.disposed {
+				return ErrDisposed
+			}
+		case <-ctx.Done():
+			d.lock.Lock()
+			select {
+			case <-w:
+				// Already signalled: hand the wake-up on to the next waiter.
+				d.signalOne(&d.notEmptyWaiters)
+			default:
+				d.removeWaiter(&d.notEmptyWaiters, w)
+			}
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
 // =================================[Push/Pop/Peek]=================================
 
 // Add an item into the front (top) of the dequeue. Blocks if dequeue is full.
+// No-op if the dequeue has been Closed.
 func (d *BlockingDequeue[T]) PushFront(item T) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
-	defer d.notEmpty.Signal()
 
 	// If the dequeue is full, wait until an item is removed
-	for d.isFull_unsafe() {
-		d.notFull.Wait()
+	if !d.waitNotFull() {
+		return
 	}
+	defer d.signalOne(&d.notEmptyWaiters)
 
 	if !d.isEmpty {
 		d.first = d.prevIndex(d.first)
@@ -65,15 +268,16 @@ func (d *BlockingDequeue[T]) PushFront(item T) {
 }
 
 // Add an item to the back (bottom) of the dequeue. Blocks if dequeue is full.
+// No-op if the dequeue has been Closed.
 func (d *BlockingDequeue[T]) PushBack(item T) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
-	defer d.notEmpty.Signal()
 
 	// If the dequeue is full, wait until an item is removed
-	for d.isFull_unsafe() {
-		d.notFull.Wait()
+	if !d.waitNotFull() {
+		return
 	}
+	defer d.signalOne(&d.notEmptyWaiters)
 
 	if !d.isEmpty {
 		d.last = d.nextIndex(d.last)
@@ -84,15 +288,17 @@ func (d *BlockingDequeue[T]) PushBack(item T) {
 }
 
 // Read the first item (on the top/front) of the dequeue and remove it. Blocks if the dequeue is empty.
+// Returns the zero value if the dequeue has been Closed.
 func (d *BlockingDequeue[T]) PopFront() T {
 	d.lock.Lock()
 	defer d.lock.Unlock()
-	defer d.notFull.Signal()
 
 	// If the dequeue is empty, wait until an item is added
-	for d.isEmpty_unsafe() {
-		d.notEmpty.Wait()
+	if !d.waitNotEmpty() {
+		var zero T
+		return zero
 	}
+	defer d.signalOne(&d.notFullWaiters)
 
 	item := d.buffer[d.first]
 
@@ -102,19 +308,25 @@ func (d *BlockingDequeue[T]) PopFront() T {
 		d.first = d.nextIndex(d.first)
 	}
 
+	if d.unbounded {
+		d.shrinkIfSparse_unsafe()
+	}
+
 	return item
 }
 
 // Read the last item (at the end/back) of the dequeue and remove it. Blocks if the dequeue is empty.
+// Returns the zero value if the dequeue has been Closed.
 func (d *BlockingDequeue[T]) PopBack() T {
 	d.lock.Lock()
 	defer d.lock.Unlock()
-	defer d.notFull.Signal()
 
 	// If the dequeue is empty, wait until an item is added
-	for d.isEmpty_unsafe() {
-		d.notEmpty.Wait()
+	if !d.waitNotEmpty() {
+		var zero T
+		return zero
 	}
+	defer d.signalOne(&d.notFullWaiters)
 
 	item := d.buffer[d.last]
 
@@ -124,41 +336,263 @@ func (d *BlockingDequeue[T]) PopBack() T {
 		d.last = d.prevIndex(d.last)
 	}
 
+	if d.unbounded {
+		d.shrinkIfSparse_unsafe()
+	}
+
 	return item
 }
 
 // Read the first item of the dequeue without removing it. Blocks if the dequeue is empty.
+// Returns the zero value if the dequeue has been Closed.
 func (d *BlockingDequeue[T]) PeekFront() T {
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
 	// If the dequeue is empty, wait until an item is added
-	for d.isEmpty_unsafe() {
-		d.notEmpty.Wait()
+	if !d.waitNotEmpty() {
+		var zero T
+		return zero
 	}
 
 	return d.buffer[d.first]
 }
 
 // Read the last item of the dequeue without removing it. Blocks if the dequeue is empty.
+// Returns the zero value if the dequeue has been Closed.
 func (d *BlockingDequeue[T]) PeekBack() T {
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
 	// If the dequeue is empty, wait until an item is added
-	for d.isEmpty_unsafe() {
-		d.notEmpty.Wait()
+	if !d.waitNotEmpty() {
+		var zero T
+		return zero
 	}
 
 	return d.buffer[d.last]
 }
 
-// ================================[Size/Capacity related]================================
-// Return the number of elements in the dequeue.
-func (d *BlockingDequeue[T]) Size() int {
+// =================================[Context-aware Push/Pop]=================================
+
+// Add an item into the front of the dequeue, blocking until there's room or ctx is done.
+// Returns ctx.Err() (context.Canceled or context.DeadlineExceeded) if ctx fires first.
+func (d *BlockingDequeue[T]) PushFrontContext(ctx context.Context, item T) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if err := d.waitNotFullContext(ctx); err != nil {
+		return err
+	}
+	defer d.signalOne(&d.notEmptyWaiters)
+
+	if !d.isEmpty {
+		d.first = d.prevIndex(d.first)
+	}
+	d.buffer[d.first] = item
+
+	d.isEmpty = false
+
+	return nil
+}
+
+// Add an item into the back of the dequeue, blocking until there's room or ctx is done.
+// Returns ctx.Err() (context.Canceled or context.DeadlineExceeded) if ctx fires first.
+func (d *BlockingDequeue[T]) PushBackContext(ctx context.Context, item T) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if err := d.waitNotFullContext(ctx); err != nil {
+		return err
+	}
+	defer d.signalOne(&d.notEmptyWaiters)
+
+	if !d.isEmpty {
+		d.last = d.nextIndex(d.last)
+	}
+	d.buffer[d.last] = item
+
+	d.isEmpty = false
+
+	return nil
+}
+
+// Read and remove the front item of the dequeue, blocking until there's an item or ctx is done.
+// Returns ctx.Err() (context.Canceled or context.DeadlineExceeded) if ctx fires first.
+func (d *BlockingDequeue[T]) PopFrontContext(ctx context.Context) (T, error) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
+	if err := d.waitNotEmptyContext(ctx); err != nil {
+		var zero T
+		return zero, err
+	}
+	defer d.signalOne(&d.notFullWaiters)
+
+	item := d.buffer[d.first]
+
+	if d.first == d.last {
+		d.isEmpty = true
+	} else {
+		d.first = d.nextIndex(d.first)
+	}
+
+	if d.unbounded {
+		d.shrinkIfSparse_unsafe()
+	}
+
+	return item, nil
+}
+
+// Read and remove the back item of the dequeue, blocking until there's an item or ctx is done.
+// Returns ctx.Err() (context.Canceled or context.DeadlineExceeded) if ctx fires first.
+func (d *BlockingDequeue[T]) PopBackContext(ctx context.Context) (T, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if err := d.waitNotEmptyContext(ctx); err != nil {
+		var zero T
+		return zero, err
+	}
+	defer d.signalOne(&d.notFullWaiters)
+
+	item := d.buffer[d.last]
+
+	if d.first == d.last {
+		d.isEmpty = true
+	} else {
+		d.last = d.prevIndex(d.last)
+	}
+
+	if d.unbounded {
+		d.shrinkIfSparse_unsafe()
+	}
+
+	return item, nil
+}
+
+// Blocks until an item is available or the timeout elapses, in which case it returns
+// context.DeadlineExceeded. Equivalent to calling PopFrontContext with a context.WithTimeout.
+func (d *BlockingDequeue[T]) PollFront(timeout time.Duration) (T, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return d.PopFrontContext(ctx)
+}
+
+// =================================[Non-blocking Push/Pop]=================================
+
+// Add an item into the front of the dequeue if there's room. Returns false without blocking
+// if the dequeue is full.
+func (d *BlockingDequeue[T]) TryPushFront(item T) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.disposed {
+		return false
+	}
+
+	if d.unbounded {
+		d.growIfFull_unsafe()
+	} else if d.isFull_unsafe() {
+		return false
+	}
+	defer d.signalOne(&d.notEmptyWaiters)
+
+	if !d.isEmpty {
+		d.first = d.prevIndex(d.first)
+	}
+	d.buffer[d.first] = item
+
+	d.isEmpty = false
+
+	return true
+}
+
+// Add an item into the back of the dequeue if there's room. Returns false without blocking
+// if the dequeue is full.
+func (d *BlockingDequeue[T]) TryPushBack(item T) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.disposed {
+		return false
+	}
+
+	if d.unbounded {
+		d.growIfFull_unsafe()
+	} else if d.isFull_unsafe() {
+		return false
+	}
+	defer d.signalOne(&d.notEmptyWaiters)
+
+	if !d.isEmpty {
+		d.last = d.nextIndex(d.last)
+	}
+	d.buffer[d.last] = item
+
+	d.isEmpty = false
+
+	return true
+}
+
+// Read and remove the front item of the dequeue if there is one. Returns false without
+// blocking if the dequeue is empty.
+func (d *BlockingDequeue[T]) TryPopFront() (T, bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.disposed || d.isEmpty_unsafe() {
+		var zero T
+		return zero, false
+	}
+	defer d.signalOne(&d.notFullWaiters)
+
+	item := d.buffer[d.first]
+
+	if d.first == d.last {
+		d.isEmpty = true
+	} else {
+		d.first = d.nextIndex(d.first)
+	}
+
+	if d.unbounded {
+		d.shrinkIfSparse_unsafe()
+	}
+
+	return item, true
+}
+
+// Read and remove the back item of the dequeue if there is one. Returns false without
+// blocking if the dequeue is empty.
+func (d *BlockingDequeue[T]) TryPopBack() (T, bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.disposed || d.isEmpty_unsafe() {
+		var zero T
+		return zero, false
+	}
+	defer d.signalOne(&d.notFullWaiters)
+
+	item := d.buffer[d.last]
+
+	if d.first == d.last {
+		d.isEmpty = true
+	} else {
+		d.last = d.prevIndex(d.last)
+	}
+
+	if d.unbounded {
+		d.shrinkIfSparse_unsafe()
+	}
+
+	return item, true
+}
+
+// ================================[Size/Capacity related]================================
+// Return the number of elements in the dequeue, without acquiring any locks.
+func (d *BlockingDequeue[T]) size_unsafe() int {
 	if d.isEmpty {
 		return 0
 	}
@@ -169,6 +603,14 @@ func (d *BlockingDequeue[T]) Size() int {
 	}
 }
 
+// Return the number of elements in the dequeue.
+func (d *BlockingDequeue[T]) Size() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.size_unsafe()
+}
+
 // Return true if the dequeue is empty, without acquiring any locks.
 // Dequeue is empty if the first and last indices are the same.
 func (d *BlockingDequeue[T]) isEmpty_unsafe() bool {
@@ -185,15 +627,508 @@ func (d *BlockingDequeue[T]) IsEmpty() bool {
 
 // Return true if the dequeue is full, without acquiring any locks.
 // Dequeue is full if the next item to be added will be the first item in the dequeue.
+// The isEmpty check disambiguates a fresh length-1 buffer (first == last == 0) from a
+// full one, since with len(buffer) == 1, nextIndex(last) == first in both cases.
 func (d *BlockingDequeue[T]) isFull_unsafe() bool {
-	return d.nextIndex(d.last) == d.first
+	return !d.isEmpty && d.nextIndex(d.last) == d.first
 }
 
 // Return true if the dequeue is full.
 // i.e. the dequeue has limited capacity and the current size is equal to that capacity.
+// Always false in unbounded mode, since the buffer grows instead of rejecting pushes.
 func (d *BlockingDequeue[T]) IsFull() bool {
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
+	if d.unbounded {
+		return false
+	}
+
 	return d.isFull_unsafe()
 }
+
+// =================================[Unbounded resizing]=================================
+// All of the following must be called while holding d.lock.
+
+// Reallocates the backing buffer to newCap, copying existing elements in logical order
+// starting from first, and resetting first=0, last=size-1.
+func (d *BlockingDequeue[T]) resize_unsafe(newCap int) {
+	size := d.size_unsafe()
+	newBuffer := make([]T, newCap)
+
+	if !d.isEmpty {
+		if d.first <= d.last {
+			copy(newBuffer, d.buffer[d.first:d.last+1])
+		} else {
+			n := copy(newBuffer, d.buffer[d.first:])
+			copy(newBuffer[n:], d.buffer[:d.last+1])
+		}
+	}
+
+	d.buffer = newBuffer
+	d.first = 0
+	if size > 0 {
+		d.last = size - 1
+	} else {
+		d.last = 0
+	}
+}
+
+// Doubles the buffer's capacity if it's currently full.
+func (d *BlockingDequeue[T]) growIfFull_unsafe() {
+	if !d.isFull_unsafe() {
+		return
+	}
+
+	d.resize_unsafe(len(d.buffer) * 2)
+}
+
+// Halves the buffer's capacity if occupancy has dropped below a quarter, down to minCapacity.
+func (d *BlockingDequeue[T]) shrinkIfSparse_unsafe() {
+	capacity := len(d.buffer)
+	if capacity <= d.minCapacity {
+		return
+	}
+
+	if d.size_unsafe()*4 >= capacity {
+		return
+	}
+
+	newCap := capacity / 2
+	if newCap < d.minCapacity {
+		newCap = d.minCapacity
+	}
+
+	d.resize_unsafe(newCap)
+}
+
+// =================================[Iteration]=================================
+
+// Returns a front-to-back snapshot of the values currently in the dequeue, without
+// acquiring any locks.
+func (d *BlockingDequeue[T]) values_unsafe() []T {
+	values := make([]T, d.size_unsafe())
+	if len(values) == 0 {
+		return values
+	}
+
+	if d.first <= d.last {
+		copy(values, d.buffer[d.first:d.last+1])
+	} else {
+		n := copy(values, d.buffer[d.first:])
+		copy(values[n:], d.buffer[:d.last+1])
+	}
+
+	return values
+}
+
+// Returns a front-to-back snapshot of the values currently in the dequeue.
+func (d *BlockingDequeue[T]) Values() []T {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.values_unsafe()
+}
+
+// Iterates front-to-back over a snapshot of the dequeue's values, calling fn with each
+// item's index and value. Stops early if fn returns false. Runs under the lock, so fn
+// should not call back into the dequeue.
+func (d *BlockingDequeue[T]) Range(fn func(index int, v T) bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for i, v := range d.values_unsafe() {
+		if !fn(i, v) {
+			return
+		}
+	}
+}
+
+// A stateful, front-to-back iterator over a snapshot of a BlockingDequeue's values, taken
+// at the time Iterator() was called. Not safe for concurrent use.
+type DequeueIterator[T any] struct {
+	values []T
+	index  int
+}
+
+// Returns an iterator over a snapshot of the dequeue's current values, so that callers can
+// inspect the dequeue without holding its lock across their own code.
+func (d *BlockingDequeue[T]) Iterator() *DequeueIterator[T] {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return &DequeueIterator[T]{
+		values: d.values_unsafe(),
+		index:  -1,
+	}
+}
+
+// Advances the iterator to the next value. Returns false once the snapshot is exhausted.
+func (it *DequeueIterator[T]) Next() bool {
+	if it.index+1 >= len(it.values) {
+		return false
+	}
+
+	it.index++
+	return true
+}
+
+// Returns the index of the current value, as returned by the last call to Next.
+func (it *DequeueIterator[T]) Index() int {
+	return it.index
+}
+
+// Returns the current value, as positioned by the last call to Next.
+func (it *DequeueIterator[T]) Value() T {
+	return it.values[it.index]
+}
+
+// Rewinds the iterator back to before the first value, without retaking the snapshot.
+func (it *DequeueIterator[T]) Reset() {
+	it.index = -1
+}
+
+// =================================[Batch Push/Pop]=================================
+
+// Above this many items signaled in one go, it's cheaper to wake every waiter once than
+// to close one waiter channel per item.
+const batchSignalBroadcastThreshold = 4
+
+// Wakes up waiters on the given list to account for count items having become available,
+// broadcasting instead of signaling one-by-one once count crosses the threshold above.
+func (d *BlockingDequeue[T]) signalForBatch(waiters *[]chan struct{}, count int) {
+	if count == 0 {
+		return
+	}
+
+	if count > batchSignalBroadcastThreshold {
+		d.broadcastAll(waiters)
+		return
+	}
+
+	for i := 0; i < count; i++ {
+		d.signalOne(waiters)
+	}
+}
+
+// Pushes items onto the back of the dequeue, in order. Acquires the lock once per batch
+// that fits, signaling notEmpty for the whole batch at once rather than per item, then
+// blocks for room and continues with the remainder if the dequeue is bounded and fills up.
+func (d *BlockingDequeue[T]) PushBackAll(items []T) {
+	for len(items) > 0 {
+		d.lock.Lock()
+
+		if d.disposed {
+			d.lock.Unlock()
+			return
+		}
+
+		inserted := 0
+		for len(items) > 0 {
+			if d.unbounded {
+				d.growIfFull_unsafe()
+			} else if d.isFull_unsafe() {
+				break
+			}
+
+			if !d.isEmpty {
+				d.last = d.nextIndex(d.last)
+			}
+			d.buffer[d.last] = items[0]
+			d.isEmpty = false
+
+			items = items[1:]
+			inserted++
+		}
+
+		d.signalForBatch(&d.notEmptyWaiters, inserted)
+
+		if len(items) == 0 {
+			d.lock.Unlock()
+			return
+		}
+
+		w := d.addWaiter(&d.notFullWaiters)
+		d.lock.Unlock()
+		<-w
+	}
+}
+
+// Pushes items onto the front of the dequeue, in order, so that items[0] ends up at the
+// front. Same batching and backpressure behavior as PushBackAll.
+func (d *BlockingDequeue[T]) PushFrontAll(items []T) {
+	for len(items) > 0 {
+		d.lock.Lock()
+
+		if d.disposed {
+			d.lock.Unlock()
+			return
+		}
+
+		inserted := 0
+		for len(items) > 0 {
+			if d.unbounded {
+				d.growIfFull_unsafe()
+			} else if d.isFull_unsafe() {
+				break
+			}
+
+			last := len(items) - 1
+			if !d.isEmpty {
+				d.first = d.prevIndex(d.first)
+			}
+			d.buffer[d.first] = items[last]
+			d.isEmpty = false
+
+			items = items[:last]
+			inserted++
+		}
+
+		d.signalForBatch(&d.notEmptyWaiters, inserted)
+
+		if len(items) == 0 {
+			d.lock.Unlock()
+			return
+		}
+
+		w := d.addWaiter(&d.notFullWaiters)
+		d.lock.Unlock()
+		<-w
+	}
+}
+
+// Pops and returns up to n items from the front of the dequeue, in front-to-back order,
+// blocking as needed until n items have been collected. Returns nil if n <= 0.
+func (d *BlockingDequeue[T]) PopFrontN(n int) []T {
+	if n <= 0 {
+		return nil
+	}
+
+	result := make([]T, 0, n)
+
+	for len(result) < n {
+		d.lock.Lock()
+
+		if d.disposed {
+			d.lock.Unlock()
+			return result
+		}
+
+		popped := 0
+		for len(result) < n && !d.isEmpty_unsafe() {
+			item := d.buffer[d.first]
+			if d.first == d.last {
+				d.isEmpty = true
+			} else {
+				d.first = d.nextIndex(d.first)
+			}
+
+			result = append(result, item)
+			popped++
+		}
+
+		if d.unbounded {
+			d.shrinkIfSparse_unsafe()
+		}
+		d.signalForBatch(&d.notFullWaiters, popped)
+
+		if len(result) == n {
+			d.lock.Unlock()
+			return result
+		}
+
+		w := d.addWaiter(&d.notEmptyWaiters)
+		d.lock.Unlock()
+		<-w
+	}
+
+	return result
+}
+
+// Pops and returns up to n items from the back of the dequeue, in back-to-front order,
+// blocking as needed until n items have been collected. Returns nil if n <= 0.
+func (d *BlockingDequeue[T]) PopBackN(n int) []T {
+	if n <= 0 {
+		return nil
+	}
+
+	result := make([]T, 0, n)
+
+	for len(result) < n {
+		d.lock.Lock()
+
+		if d.disposed {
+			d.lock.Unlock()
+			return result
+		}
+
+		popped := 0
+		for len(result) < n && !d.isEmpty_unsafe() {
+			item := d.buffer[d.last]
+			if d.first == d.last {
+				d.isEmpty = true
+			} else {
+				d.last = d.prevIndex(d.last)
+			}
+
+			result = append(result, item)
+			popped++
+		}
+
+		if d.unbounded {
+			d.shrinkIfSparse_unsafe()
+		}
+		d.signalForBatch(&d.notFullWaiters, popped)
+
+		if len(result) == n {
+			d.lock.Unlock()
+			return result
+		}
+
+		w := d.addWaiter(&d.notEmptyWaiters)
+		d.lock.Unlock()
+		<-w
+	}
+
+	return result
+}
+
+// Copies up to max items (and no more than len(dst)) from the front of the dequeue into
+// dst, removing them, and returns the number of items copied. Never blocks. Returns 0 if
+// max <= 0.
+func (d *BlockingDequeue[T]) DrainTo(dst []T, max int) int {
+	if max <= 0 {
+		return 0
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	n := max
+	if len(dst) < n {
+		n = len(dst)
+	}
+	if size := d.size_unsafe(); size < n {
+		n = size
+	}
+
+	for i := 0; i < n; i++ {
+		dst[i] = d.buffer[d.first]
+		if d.first == d.last {
+			d.isEmpty = true
+		} else {
+			d.first = d.nextIndex(d.first)
+		}
+	}
+
+	if d.unbounded {
+		d.shrinkIfSparse_unsafe()
+	}
+	d.signalForBatch(&d.notFullWaiters, n)
+
+	return n
+}
+
+// =================================[Lifecycle]=================================
+
+// Disposes of the dequeue: wakes up every blocked goroutine (with ErrDisposed for the
+// context-aware variants, the zero value for the plain ones), closes the channel returned
+// by Closed, and makes every subsequent operation return immediately. Safe to call more
+// than once.
+func (d *BlockingDequeue[T]) Close() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.disposed {
+		return
+	}
+
+	d.disposed = true
+	close(d.closedCh)
+
+	d.broadcastAll(&d.notEmptyWaiters)
+	d.broadcastAll(&d.notFullWaiters)
+}
+
+// Returns a channel that's closed once Close has been called, for select-based shutdown.
+func (d *BlockingDequeue[T]) Closed() <-chan struct{} {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.closedCh
+}
+
+// Clears the dequeue's contents and, if it had been Closed, un-disposes it, so that it can
+// be reused (e.g. from a pool) as if freshly constructed. Capacity and unbounded-ness are
+// left untouched. Any goroutine still blocked in a wait from before the reset is woken up
+// to re-check the now empty dequeue: blocked pushes then go ahead, blocked pops wait again.
+func (d *BlockingDequeue[T]) Reset() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.buffer = make([]T, len(d.buffer))
+	d.first = 0
+	d.last = 0
+	d.isEmpty = true
+
+	d.broadcastAll(&d.notEmptyWaiters)
+	d.broadcastAll(&d.notFullWaiters)
+
+	if d.disposed {
+		d.disposed = false
+		d.closedCh = make(chan struct{})
+	}
+}
+
+// Spawns a goroutine that forwards items sent on the returned channel into the dequeue's
+// back, and returns its send-only end. The goroutine exits once the dequeue is Closed, even
+// if no further items are ever sent on the returned channel. Unlike Consumer, delivery isn't
+// guaranteed across a Close: an item already received off the returned channel is dropped if
+// the dequeue is (or becomes) disposed before it can be pushed, since there's no dequeue left
+// to push it onto.
+func (d *BlockingDequeue[T]) Producer() chan<- T {
+	ch := make(chan T)
+
+	go func() {
+		for {
+			select {
+			case item, ok := <-ch:
+				if !ok {
+					return
+				}
+
+				if err := d.PushBackContext(context.Background(), item); err != nil {
+					return
+				}
+			case <-d.Closed():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// Spawns a goroutine that pops items off the dequeue's front and forwards them onto the
+// returned channel, which is closed once the dequeue is Closed. An item that's already been
+// popped off the dequeue is always delivered on the channel, even if Close is called before
+// it's received; as a consequence, the goroutine can linger past Close until that last item
+// is read.
+func (d *BlockingDequeue[T]) Consumer() <-chan T {
+	ch := make(chan T)
+
+	go func() {
+		defer close(ch)
+
+		for {
+			item, err := d.PopFrontContext(context.Background())
+			if err != nil {
+				return
+			}
+
+			ch <- item
+		}
+	}()
+
+	return ch
+}
@@ -1,6 +1,7 @@
 package blocking_dequeue
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
@@ -106,7 +107,7 @@ func TestBlockingPushFront(t *testing.T) {
 	// Remove the element to empty the dequeue and unblock the goroutine
 	dequeue.lock.Lock()
 	dequeue.first = dequeue.nextIndex(dequeue.first)
-	dequeue.notFull.Signal()
+	dequeue.signalOne(&dequeue.notFullWaiters)
 	dequeue.lock.Unlock()
 	wg.Wait()
 
@@ -153,7 +154,7 @@ func TestBlockingPushBack(t *testing.T) {
 	// Remove the element to empty the dequeue and unblock the goroutine
 	dequeue.lock.Lock()
 	dequeue.last = dequeue.prevIndex(dequeue.last)
-	dequeue.notFull.Signal()
+	dequeue.signalOne(&dequeue.notFullWaiters)
 	dequeue.lock.Unlock()
 	wg.Wait()
 
@@ -387,3 +388,386 @@ func TestIsEmptyAfterUpdates(t *testing.T) {
 		t.Errorf("Expected true, got false")
 	}
 }
+
+func TestTryPushAndTryPop(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 1))
+
+	if !dequeue.TryPushBack(1) {
+		t.Errorf("Expected true, got false")
+	}
+
+	if dequeue.TryPushFront(2) {
+		t.Errorf("Expected false, got true")
+	}
+
+	value, ok := dequeue.TryPopFront()
+	if !ok || value != 1 {
+		t.Errorf("Expected (1, true), got (%d, %t)", value, ok)
+	}
+
+	_, ok = dequeue.TryPopFront()
+	if ok {
+		t.Errorf("Expected false, got true")
+	}
+}
+
+func TestPushFrontContextCancelled(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 1))
+	dequeue.PushFront(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- dequeue.PushFrontContext(ctx, 2)
+	}()
+
+	// Sleep to make sure that the above goroutine is started and blocked
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	if dequeue.Size() != 1 {
+		t.Errorf("Expected size 1, got %d", dequeue.Size())
+	}
+}
+
+func TestPopFrontContextSucceeds(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 5))
+
+	done := make(chan int, 1)
+	go func() {
+		value, err := dequeue.PopFrontContext(context.Background())
+		if err != nil {
+			t.Errorf("Expected nil error, got %v", err)
+		}
+		done <- value
+	}()
+
+	// Sleep to make sure that the above goroutine is started and blocked
+	time.Sleep(100 * time.Millisecond)
+	dequeue.PushFront(1)
+
+	if value := <-done; value != 1 {
+		t.Errorf("Expected 1, got %d", value)
+	}
+}
+
+func TestPollFrontTimesOut(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 5))
+
+	_, err := dequeue.PollFront(50 * time.Millisecond)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestUnboundedNeverBlocksAndGrows(t *testing.T) {
+	dequeue := NewUnboundedBlockingDequeue[int](2)
+
+	for i := 0; i < 10; i++ {
+		dequeue.PushBack(i)
+	}
+
+	if dequeue.IsFull() {
+		t.Errorf("Expected false, got true")
+	}
+
+	if dequeue.Size() != 10 {
+		t.Errorf("Expected size 10, got %d", dequeue.Size())
+	}
+
+	if len(dequeue.buffer) < 10 {
+		t.Errorf("Expected buffer to have grown to at least 10, got %d", len(dequeue.buffer))
+	}
+
+	for i := 0; i < 10; i++ {
+		value := dequeue.PopFront()
+		if value != i {
+			t.Errorf("Expected %d, got %d", i, value)
+		}
+	}
+}
+
+func TestUnboundedShrinksBackToMinCapacity(t *testing.T) {
+	dequeue := NewUnboundedBlockingDequeue[int](2)
+
+	for i := 0; i < 20; i++ {
+		dequeue.PushBack(i)
+	}
+
+	for i := 0; i < 20; i++ {
+		dequeue.PopFront()
+	}
+
+	if len(dequeue.buffer) != dequeue.minCapacity {
+		t.Errorf("Expected buffer to shrink back to %d, got %d", dequeue.minCapacity, len(dequeue.buffer))
+	}
+}
+
+func TestUnboundedGrowthPreservesWrappedOrder(t *testing.T) {
+	dequeue := NewUnboundedBlockingDequeue[int](4)
+
+	// Wrap the buffer around before triggering growth
+	dequeue.PushBack(1)
+	dequeue.PushBack(2)
+	dequeue.PopFront()
+	dequeue.PopFront()
+
+	dequeue.PushBack(3)
+	dequeue.PushBack(4)
+	dequeue.PushBack(5)
+	dequeue.PushBack(6)
+	dequeue.PushBack(7)
+
+	for _, expected := range []int{3, 4, 5, 6, 7} {
+		value := dequeue.PopFront()
+		if value != expected {
+			t.Errorf("Expected %d, got %d", expected, value)
+		}
+	}
+}
+
+func TestValuesWrappedOrder(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 5))
+
+	dequeue.PushBack(1)
+	dequeue.PushBack(2)
+	dequeue.PushBack(3)
+	dequeue.PushFront(0)
+
+	values := dequeue.Values()
+	expected := []int{0, 1, 2, 3}
+
+	if len(values) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, values)
+	}
+
+	for i, v := range expected {
+		if values[i] != v {
+			t.Errorf("Expected %v, got %v", expected, values)
+		}
+	}
+}
+
+func TestRangeStopsEarly(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 5))
+	dequeue.PushBack(1)
+	dequeue.PushBack(2)
+	dequeue.PushBack(3)
+
+	visited := []int{}
+	dequeue.Range(func(index int, v int) bool {
+		visited = append(visited, v)
+		return index < 1
+	})
+
+	if len(visited) != 2 {
+		t.Errorf("Expected 2 visited items, got %d", len(visited))
+	}
+}
+
+func TestIterator(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 5))
+	dequeue.PushBack(1)
+	dequeue.PushBack(2)
+	dequeue.PushBack(3)
+
+	it := dequeue.Iterator()
+	dequeue.PopFront() // Mutating the dequeue must not affect an already-taken snapshot
+
+	visited := []int{}
+	for it.Next() {
+		visited = append(visited, it.Value())
+		if it.Index() != len(visited)-1 {
+			t.Errorf("Expected index %d, got %d", len(visited)-1, it.Index())
+		}
+	}
+
+	if len(visited) != 3 || visited[0] != 1 || visited[1] != 2 || visited[2] != 3 {
+		t.Errorf("Expected [1 2 3], got %v", visited)
+	}
+
+	it.Reset()
+	if !it.Next() || it.Value() != 1 {
+		t.Errorf("Expected first value 1 after Reset, got %d", it.Value())
+	}
+}
+
+func TestPushBackAllAndPopFrontN(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 10))
+
+	dequeue.PushBackAll([]int{1, 2, 3, 4})
+
+	values := dequeue.PopFrontN(4)
+	expected := []int{1, 2, 3, 4}
+
+	for i, v := range expected {
+		if values[i] != v {
+			t.Errorf("Expected %v, got %v", expected, values)
+		}
+	}
+}
+
+func TestPushFrontAllPreservesOrder(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 10))
+
+	dequeue.PushBack(4)
+	dequeue.PushFrontAll([]int{1, 2, 3})
+
+	values := dequeue.Values()
+	expected := []int{1, 2, 3, 4}
+
+	for i, v := range expected {
+		if values[i] != v {
+			t.Errorf("Expected %v, got %v", expected, values)
+		}
+	}
+}
+
+func TestPushBackAllBlocksForRemainder(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 2))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		dequeue.PushBackAll([]int{1, 2, 3})
+	}()
+
+	// Sleep to make sure that the above goroutine is started and blocked on the 3rd item
+	time.Sleep(100 * time.Millisecond)
+
+	if dequeue.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", dequeue.Size())
+	}
+
+	dequeue.PopFront()
+	<-done
+
+	if dequeue.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", dequeue.Size())
+	}
+}
+
+func TestPopBackN(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 10))
+	dequeue.PushBackAll([]int{1, 2, 3})
+
+	values := dequeue.PopBackN(3)
+	expected := []int{3, 2, 1}
+
+	for i, v := range expected {
+		if values[i] != v {
+			t.Errorf("Expected %v, got %v", expected, values)
+		}
+	}
+}
+
+func TestDrainToDoesNotBlock(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 10))
+	dequeue.PushBackAll([]int{1, 2, 3})
+
+	dst := make([]int, 5)
+	n := dequeue.DrainTo(dst, 5)
+
+	if n != 3 {
+		t.Errorf("Expected 3, got %d", n)
+	}
+
+	expected := []int{1, 2, 3}
+	for i, v := range expected {
+		if dst[i] != v {
+			t.Errorf("Expected %v, got %v", expected, dst[:n])
+		}
+	}
+
+	if !dequeue.IsEmpty() {
+		t.Errorf("Expected true, got false")
+	}
+}
+
+func TestCloseWakesBlockedContextCall(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 5))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := dequeue.PopFrontContext(context.Background())
+		done <- err
+	}()
+
+	// Sleep to make sure that the above goroutine is started and blocked
+	time.Sleep(100 * time.Millisecond)
+	dequeue.Close()
+
+	if err := <-done; err != ErrDisposed {
+		t.Errorf("Expected ErrDisposed, got %v", err)
+	}
+
+	select {
+	case <-dequeue.Closed():
+	default:
+		t.Errorf("Expected Closed channel to be closed")
+	}
+}
+
+func TestCloseUnblocksPlainPop(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 5))
+
+	done := make(chan int, 1)
+	go func() {
+		done <- dequeue.PopFront()
+	}()
+
+	// Sleep to make sure that the above goroutine is started and blocked
+	time.Sleep(100 * time.Millisecond)
+	dequeue.Close()
+
+	if value := <-done; value != 0 {
+		t.Errorf("Expected zero value 0, got %d", value)
+	}
+}
+
+func TestResetReenablesDisposedDequeue(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 5))
+	dequeue.PushBack(1)
+	dequeue.Close()
+
+	dequeue.Reset()
+
+	if !dequeue.TryPushBack(2) {
+		t.Errorf("Expected true, got false")
+	}
+
+	value, ok := dequeue.TryPopFront()
+	if !ok || value != 2 {
+		t.Errorf("Expected (2, true), got (%d, %t)", value, ok)
+	}
+}
+
+func TestProducerConsumerBridge(t *testing.T) {
+	dequeue := NewBlockingDequeue(make([]int, 5))
+
+	producer := dequeue.Producer()
+	consumer := dequeue.Consumer()
+
+	go func() {
+		for i := 1; i <= 3; i++ {
+			producer <- i
+		}
+	}()
+
+	for i := 1; i <= 3; i++ {
+		if value := <-consumer; value != i {
+			t.Errorf("Expected %d, got %d", i, value)
+		}
+	}
+
+	dequeue.Close()
+
+	if _, ok := <-consumer; ok {
+		t.Errorf("Expected consumer channel to be closed after Close")
+	}
+}
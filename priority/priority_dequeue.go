@@ -0,0 +1,244 @@
+package priority_blocking_dequeue
+
+import (
+	"sync"
+)
+
+// Which end of the ordering PopMin/PopMax is expected to be called against. The heap is
+// always organized so that its root is whichever end matches the configured Mode.
+type Mode int
+
+const (
+	// Min arranges the heap so PopMin/Peek return the smallest item first.
+	Min Mode = iota
+	// Max arranges the heap so PopMax/Peek return the largest item first.
+	Max
+)
+
+// Blocking priority dequeue, implemented with a binary heap over a growable slice.
+// The dequeue is thread safe, and must not be copied.
+type PriorityBlockingDequeue[T any] struct {
+	heap []T
+	less func(a, b T) bool
+	mode Mode
+
+	// capacity is the maximum number of items allowed when bounded is true; Push never
+	// blocks when bounded is false.
+	capacity int
+	bounded  bool
+
+	lock *sync.Mutex
+
+	// Waiters are notified by closing their channel, mirroring the waiter-list signaling
+	// used by the plain BlockingDequeue.
+	notEmptyWaiters, notFullWaiters []chan struct{}
+}
+
+// Creates a new bounded blocking priority dequeue with the given capacity, ordering items
+// with less: for Min mode the root (and PopMin/Peek) is the item less ranks smallest;
+// for Max mode it's the item less ranks largest.
+func NewPriorityBlockingDequeue[T any](less func(a, b T) bool, mode Mode, capacity int) *PriorityBlockingDequeue[T] {
+	d := newPriorityBlockingDequeue(less, mode)
+
+	d.bounded = true
+	d.capacity = capacity
+
+	return d
+}
+
+// Creates a new unbounded blocking priority dequeue: Push never blocks.
+func NewUnboundedPriorityBlockingDequeue[T any](less func(a, b T) bool, mode Mode) *PriorityBlockingDequeue[T] {
+	return newPriorityBlockingDequeue(less, mode)
+}
+
+func newPriorityBlockingDequeue[T any](less func(a, b T) bool, mode Mode) *PriorityBlockingDequeue[T] {
+	d := new(PriorityBlockingDequeue[T])
+
+	d.mode = mode
+	if mode == Max {
+		d.less = func(a, b T) bool { return less(b, a) }
+	} else {
+		d.less = less
+	}
+
+	d.lock = &sync.Mutex{}
+
+	return d
+}
+
+// =================================[Waiter helpers]=================================
+// All of the following must be called while holding d.lock.
+
+func (d *PriorityBlockingDequeue[T]) addWaiter(waiters *[]chan struct{}) chan struct{} {
+	w := make(chan struct{})
+	*waiters = append(*waiters, w)
+	return w
+}
+
+func (d *PriorityBlockingDequeue[T]) signalOne(waiters *[]chan struct{}) {
+	if len(*waiters) == 0 {
+		return
+	}
+
+	w := (*waiters)[0]
+	*waiters = (*waiters)[1:]
+	close(w)
+}
+
+// Blocks until the heap is not full. Must be called while holding d.lock, which is
+// released while actually waiting and re-acquired before returning. Never blocks when
+// the dequeue is unbounded.
+func (d *PriorityBlockingDequeue[T]) waitNotFull() {
+	for d.isFull_unsafe() {
+		w := d.addWaiter(&d.notFullWaiters)
+		d.lock.Unlock()
+		<-w
+		d.lock.Lock()
+	}
+}
+
+// Blocks until the heap is not empty. Same locking contract as waitNotFull.
+func (d *PriorityBlockingDequeue[T]) waitNotEmpty() {
+	for len(d.heap) == 0 {
+		w := d.addWaiter(&d.notEmptyWaiters)
+		d.lock.Unlock()
+		<-w
+		d.lock.Lock()
+	}
+}
+
+// =================================[Heap helpers]=================================
+// All of the following must be called while holding d.lock.
+
+func (d *PriorityBlockingDequeue[T]) siftUp(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !d.less(d.heap[i], d.heap[parent]) {
+			break
+		}
+
+		d.heap[i], d.heap[parent] = d.heap[parent], d.heap[i]
+		i = parent
+	}
+}
+
+func (d *PriorityBlockingDequeue[T]) siftDown(i int) {
+	n := len(d.heap)
+
+	for {
+		top := i
+		left, right := 2*i+1, 2*i+2
+
+		if left < n && d.less(d.heap[left], d.heap[top]) {
+			top = left
+		}
+		if right < n && d.less(d.heap[right], d.heap[top]) {
+			top = right
+		}
+		if top == i {
+			break
+		}
+
+		d.heap[i], d.heap[top] = d.heap[top], d.heap[i]
+		i = top
+	}
+}
+
+func (d *PriorityBlockingDequeue[T]) isFull_unsafe() bool {
+	return d.bounded && len(d.heap) >= d.capacity
+}
+
+// =================================[Push/Pop/Peek]=================================
+
+// Add an item to the dequeue. Blocks if the dequeue is bounded and full.
+func (d *PriorityBlockingDequeue[T]) Push(item T) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	defer d.signalOne(&d.notEmptyWaiters)
+
+	// If the dequeue is full, wait until an item is removed
+	d.waitNotFull()
+
+	d.heap = append(d.heap, item)
+	d.siftUp(len(d.heap) - 1)
+}
+
+// Removes and returns the root of the heap. Blocks if the dequeue is empty.
+func (d *PriorityBlockingDequeue[T]) pop() T {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	defer d.signalOne(&d.notFullWaiters)
+
+	// If the dequeue is empty, wait until an item is added
+	d.waitNotEmpty()
+
+	root := d.heap[0]
+
+	last := len(d.heap) - 1
+	d.heap[0] = d.heap[last]
+	d.heap = d.heap[:last]
+
+	if len(d.heap) > 0 {
+		d.siftDown(0)
+	}
+
+	return root
+}
+
+// Removes and returns the smallest item in the dequeue, as ordered by less.
+// Blocks if the dequeue is empty. Panics if the dequeue wasn't constructed with Min mode,
+// since the heap isn't kept in an order that would make the answer meaningful otherwise.
+func (d *PriorityBlockingDequeue[T]) PopMin() T {
+	if d.mode != Min {
+		panic("blocking_dequeue: PopMin called on a dequeue constructed with Max mode")
+	}
+
+	return d.pop()
+}
+
+// Removes and returns the largest item in the dequeue, as ordered by less.
+// Blocks if the dequeue is empty. Panics if the dequeue wasn't constructed with Max mode,
+// since the heap isn't kept in an order that would make the answer meaningful otherwise.
+func (d *PriorityBlockingDequeue[T]) PopMax() T {
+	if d.mode != Max {
+		panic("blocking_dequeue: PopMax called on a dequeue constructed with Min mode")
+	}
+
+	return d.pop()
+}
+
+// Returns the root of the heap without removing it. Blocks if the dequeue is empty.
+func (d *PriorityBlockingDequeue[T]) Peek() T {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.waitNotEmpty()
+
+	return d.heap[0]
+}
+
+// ================================[Size/Capacity related]================================
+
+// Return the number of elements in the dequeue.
+func (d *PriorityBlockingDequeue[T]) Size() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return len(d.heap)
+}
+
+// Return true if the dequeue is empty.
+func (d *PriorityBlockingDequeue[T]) IsEmpty() bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return len(d.heap) == 0
+}
+
+// Return true if the dequeue is full. Always false when unbounded.
+func (d *PriorityBlockingDequeue[T]) IsFull() bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.isFull_unsafe()
+}
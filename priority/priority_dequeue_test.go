@@ -0,0 +1,133 @@
+package priority_blocking_dequeue
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPopMinOrdering(t *testing.T) {
+	dequeue := NewUnboundedPriorityBlockingDequeue(func(a, b int) bool { return a < b }, Min)
+
+	dequeue.Push(5)
+	dequeue.Push(1)
+	dequeue.Push(3)
+	dequeue.Push(2)
+	dequeue.Push(4)
+
+	for i := 1; i <= 5; i++ {
+		value := dequeue.PopMin()
+		if value != i {
+			t.Errorf("Expected %d, got %d", i, value)
+		}
+	}
+}
+
+func TestPopMaxOrdering(t *testing.T) {
+	dequeue := NewUnboundedPriorityBlockingDequeue(func(a, b int) bool { return a < b }, Max)
+
+	dequeue.Push(5)
+	dequeue.Push(1)
+	dequeue.Push(3)
+	dequeue.Push(2)
+	dequeue.Push(4)
+
+	for i := 5; i >= 1; i-- {
+		value := dequeue.PopMax()
+		if value != i {
+			t.Errorf("Expected %d, got %d", i, value)
+		}
+	}
+}
+
+func TestPeekDoesNotRemove(t *testing.T) {
+	dequeue := NewUnboundedPriorityBlockingDequeue(func(a, b int) bool { return a < b }, Min)
+	dequeue.Push(2)
+	dequeue.Push(1)
+
+	if value := dequeue.Peek(); value != 1 {
+		t.Errorf("Expected 1, got %d", value)
+	}
+
+	if dequeue.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", dequeue.Size())
+	}
+}
+
+func TestPopMaxPanicsInMinMode(t *testing.T) {
+	dequeue := NewUnboundedPriorityBlockingDequeue(func(a, b int) bool { return a < b }, Min)
+	dequeue.Push(1)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected PopMax to panic on a Min-mode dequeue")
+		}
+	}()
+
+	dequeue.PopMax()
+}
+
+func TestIsFull(t *testing.T) {
+	dequeue := NewPriorityBlockingDequeue(func(a, b int) bool { return a < b }, Min, 2)
+
+	dequeue.Push(1)
+	dequeue.Push(2)
+
+	if !dequeue.IsFull() {
+		t.Errorf("Expected true, got false")
+	}
+}
+
+func TestBlockingPush(t *testing.T) {
+	dequeue := NewPriorityBlockingDequeue(func(a, b int) bool { return a < b }, Min, 1)
+	dequeue.Push(1)
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		dequeue.Push(2)
+	}()
+
+	// Sleep to make sure that the above goroutine is started and blocked
+	time.Sleep(100 * time.Millisecond)
+
+	if dequeue.Size() != 1 {
+		t.Errorf("Expected size 1, got %d", dequeue.Size())
+	}
+
+	dequeue.PopMin()
+	wg.Wait()
+
+	if dequeue.Size() != 1 {
+		t.Errorf("Expected size 1, got %d", dequeue.Size())
+	}
+}
+
+func TestBlockingPopMin(t *testing.T) {
+	dequeue := NewUnboundedPriorityBlockingDequeue(func(a, b int) bool { return a < b }, Min)
+
+	value := -1
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		value = dequeue.PopMin()
+	}()
+
+	// Sleep to make sure that the above goroutine is started and blocked
+	time.Sleep(100 * time.Millisecond)
+
+	if value != -1 {
+		t.Errorf("Expected -1, got %d", value)
+	}
+
+	dequeue.Push(7)
+	wg.Wait()
+
+	if value != 7 {
+		t.Errorf("Expected 7, got %d", value)
+	}
+}